@@ -0,0 +1,164 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-09-01/policy"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+)
+
+func dataSourceArmPolicyDefinition() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmPolicyDefinitionRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"management_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"policy_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"policy_rule": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"metadata": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"parameters": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmPolicyDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).policyDefinitionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	displayName := d.Get("display_name").(string)
+	managementGroupID := d.Get("management_group_id").(string)
+
+	if name == "" && displayName == "" {
+		return fmt.Errorf("one of `name` or `display_name` must be specified")
+	}
+
+	var definition policy.Definition
+
+	if name != "" {
+		var err error
+		definition, err = getPolicyDefinitionByName(ctx, client, name, managementGroupID, false)
+		if err != nil {
+			return fmt.Errorf("Error loading Policy Definition %q: %+v", name, err)
+		}
+	} else {
+		// built-in definitions can only be looked up by a ListBuiltIn scan, so page through the
+		// full list comparing display names case-insensitively until we find a deterministic match
+		iterator, err := client.ListBuiltIn(ctx)
+		if err != nil {
+			return fmt.Errorf("Error loading Policy Definition List: %+v", err)
+		}
+
+		var matches []policy.Definition
+		for iterator.NotDone() {
+			for _, candidate := range iterator.Values() {
+				if candidate.DisplayName != nil && strings.EqualFold(*candidate.DisplayName, displayName) {
+					matches = append(matches, candidate)
+				}
+			}
+
+			if err := iterator.NextWithContext(ctx); err != nil {
+				return fmt.Errorf("Error loading Policy Definition List: %+v", err)
+			}
+		}
+
+		if len(matches) == 0 {
+			return fmt.Errorf("No Built-In Policy Definition found matching display_name %q", displayName)
+		}
+
+		if len(matches) > 1 {
+			return fmt.Errorf("More than one Built-In Policy Definition found matching display_name %q", displayName)
+		}
+
+		definition = matches[0]
+	}
+
+	if definition.ID == nil {
+		return fmt.Errorf("Policy Definition %q returned with a nil ID", name)
+	}
+
+	d.SetId(*definition.ID)
+	d.Set("name", definition.Name)
+	d.Set("type", definition.Type)
+
+	if props := definition.DefinitionProperties; props != nil {
+		d.Set("policy_type", props.PolicyType)
+		d.Set("display_name", props.DisplayName)
+		d.Set("description", props.Description)
+
+		if policyRule := props.PolicyRule; policyRule != nil {
+			policyRuleVal := policyRule.(map[string]interface{})
+			policyRuleStr, err := structure.FlattenJsonToString(policyRuleVal)
+			if err != nil {
+				return fmt.Errorf("unable to flatten JSON for `policy_rule`: %s", err)
+			}
+
+			d.Set("policy_rule", policyRuleStr)
+		}
+
+		if metadata := props.Metadata; metadata != nil {
+			metadataVal := metadata.(map[string]interface{})
+			metadataStr, err := structure.FlattenJsonToString(metadataVal)
+			if err != nil {
+				return fmt.Errorf("unable to flatten JSON for `metadata`: %s", err)
+			}
+
+			d.Set("metadata", metadataStr)
+		}
+
+		if parameters := props.Parameters; parameters != nil {
+			paramsVal := props.Parameters.(map[string]interface{})
+			parametersStr, err := structure.FlattenJsonToString(paramsVal)
+			if err != nil {
+				return fmt.Errorf("unable to flatten JSON for `parameters`: %s", err)
+			}
+
+			d.Set("parameters", parametersStr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package azurerm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMPolicyDefinition_builtIn(t *testing.T) {
+	dataSourceName := "data.azurerm_policy_definition.test"
+	config := testAccDataSourceAzureRMPolicyDefinition_builtIn()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "display_name", "Allowed locations"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMPolicyDefinition_builtIn() string {
+	return `
+data "azurerm_policy_definition" "test" {
+  display_name = "Allowed locations"
+}
+`
+}
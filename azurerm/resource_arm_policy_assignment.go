@@ -0,0 +1,232 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-09-01/policy"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmPolicyAssignment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmPolicyAssignmentCreateUpdate,
+		Update: resourceArmPolicyAssignmentCreateUpdate,
+		Read:   resourceArmPolicyAssignmentRead,
+		Delete: resourceArmPolicyAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"scope": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_definition_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"parameters": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.ValidateJsonString,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+
+			"not_scopes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceArmPolicyAssignmentCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	assignmentsClient := meta.(*ArmClient).policyAssignmentsClient
+	definitionsClient := meta.(*ArmClient).policyDefinitionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	scope := d.Get("scope").(string)
+	policyDefinitionID := d.Get("policy_definition_id").(string)
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+
+	// the referenced Policy Definition is itself eventually consistent, so wait for it to become
+	// visible rather than forcing users to add an explicit depends_on plus a manual sleep
+	if err := waitForPolicyDefinitionToExist(ctx, definitionsClient, policyDefinitionID); err != nil {
+		return err
+	}
+
+	properties := policy.AssignmentProperties{
+		PolicyDefinitionID: utils.String(policyDefinitionID),
+		DisplayName:        utils.String(displayName),
+		Description:        utils.String(description),
+		Scope:              utils.String(scope),
+	}
+
+	notScopesRaw := d.Get("not_scopes").([]interface{})
+	properties.NotScopes = utils.ExpandStringArray(notScopesRaw)
+
+	if parametersString := d.Get("parameters").(string); parametersString != "" {
+		parameters, err := structure.ExpandJsonFromString(parametersString)
+		if err != nil {
+			return fmt.Errorf("unable to parse parameters: %s", err)
+		}
+		properties.Parameters = &parameters
+	}
+
+	assignment := policy.Assignment{
+		Name:                 utils.String(name),
+		AssignmentProperties: &properties,
+	}
+
+	if _, err := assignmentsClient.Create(ctx, scope, name, assignment); err != nil {
+		return err
+	}
+
+	resp, err := assignmentsClient.Get(ctx, scope, name)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*resp.ID)
+
+	return resourceArmPolicyAssignmentRead(d, meta)
+}
+
+func resourceArmPolicyAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).policyAssignmentsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	scope, name, err := parsePolicyAssignmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, scope, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Error reading Policy Assignment %q - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Policy Assignment %+v", err)
+	}
+
+	d.Set("name", resp.Name)
+
+	if props := resp.AssignmentProperties; props != nil {
+		d.Set("scope", props.Scope)
+		d.Set("policy_definition_id", props.PolicyDefinitionID)
+		d.Set("display_name", props.DisplayName)
+		d.Set("description", props.Description)
+		d.Set("not_scopes", utils.FlattenStringArray(props.NotScopes))
+
+		if parameters := props.Parameters; parameters != nil {
+			paramsVal := parameters.(map[string]interface{})
+			parametersStr, err := structure.FlattenJsonToString(paramsVal)
+			if err != nil {
+				return fmt.Errorf("unable to flatten JSON for `parameters`: %s", err)
+			}
+
+			d.Set("parameters", parametersStr)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmPolicyAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).policyAssignmentsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	scope, name, err := parsePolicyAssignmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Delete(ctx, scope, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Policy Assignment %q: %+v", name, err)
+	}
+
+	return nil
+}
+
+func parsePolicyAssignmentId(id string) (scope string, name string, err error) {
+	components := strings.Split(id, "/providers/Microsoft.Authorization/policyAssignments/")
+
+	if len(components) != 2 {
+		return "", "", fmt.Errorf("Azure Policy Assignment Id is not formatted correctly: %s", id)
+	}
+
+	return components[0], components[1], nil
+}
+
+// waitForPolicyDefinitionToExist polls the given Policy Definition until it becomes visible,
+// reusing policyEventualConsistencyRefreshFunc (factored out of policyDefinitionRefreshFunc) so
+// Policy Assignments don't race the same eventual-consistency window that
+// resourceArmPolicyDefinitionCreateUpdate already waits out for its own create. Built-in
+// definitions are static - they can't be "not yet visible" - so the wait is skipped for them
+// rather than polling policyDefinitionRefreshFunc, which can only look up non-built-ins.
+func waitForPolicyDefinitionToExist(ctx context.Context, client policy.DefinitionsClient, policyDefinitionID string) error {
+	name, managementGroupID, isBuiltIn, err := parsePolicyDefinitionNameFromId(policyDefinitionID)
+	if err != nil {
+		return err
+	}
+
+	if isBuiltIn {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Waiting for Policy Definition %q to become available before creating the Policy Assignment", policyDefinitionID)
+	stateConf := &resource.StateChangeConf{
+		Pending:                   []string{"404"},
+		Target:                    []string{"200"},
+		Refresh:                   policyDefinitionRefreshFunc(ctx, client, name, managementGroupID),
+		Timeout:                   5 * time.Minute,
+		MinTimeout:                10 * time.Second,
+		ContinuousTargetOccurence: 10,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Policy Definition %q to become available: %s", policyDefinitionID, err)
+	}
+
+	return nil
+}
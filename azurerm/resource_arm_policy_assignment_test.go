@@ -0,0 +1,175 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestParsePolicyAssignmentId(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Input         string
+		ExpectedScope string
+		ExpectedName  string
+		ExpectError   bool
+	}{
+		{
+			Name:          "valid",
+			Input:         "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/policyAssignments/test",
+			ExpectedScope: "/subscriptions/00000000-0000-0000-0000-000000000000",
+			ExpectedName:  "test",
+		},
+		{
+			Name:        "invalid",
+			Input:       "/subscriptions/00000000-0000-0000-0000-000000000000",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			scope, name, err := parsePolicyAssignmentId(tc.Input)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("Expected an error but didn't get one")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if scope != tc.ExpectedScope {
+				t.Fatalf("Expected scope %q but got %q", tc.ExpectedScope, scope)
+			}
+
+			if name != tc.ExpectedName {
+				t.Fatalf("Expected name %q but got %q", tc.ExpectedName, name)
+			}
+		})
+	}
+}
+
+func TestAccAzureRMPolicyAssignment_basic(t *testing.T) {
+	resourceName := "azurerm_policy_assignment.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMPolicyAssignment_basic(ri)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMPolicyAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMPolicyAssignmentExists(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMPolicyAssignmentExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		scope := rs.Primary.Attributes["scope"]
+		name := rs.Primary.Attributes["name"]
+		client := testAccProvider.Meta().(*ArmClient).policyAssignmentsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, scope, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on policyAssignmentsClient: %+v", err)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: Policy Assignment %q does not exist", name)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMPolicyAssignmentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).policyAssignmentsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_policy_assignment" {
+			continue
+		}
+
+		scope := rs.Primary.Attributes["scope"]
+		name := rs.Primary.Attributes["name"]
+
+		resp, err := client.Get(ctx, scope, name)
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Policy Assignment still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccAzureRMPolicyAssignment_basic(ri int) string {
+	return fmt.Sprintf(`
+data "azurerm_subscription" "current" {}
+
+resource "azurerm_policy_definition" "test" {
+  name         = "acctestpol-%d"
+  policy_type  = "Custom"
+  mode         = "All"
+  display_name = "acctestpol-%d"
+
+  policy_rule = <<POLICY_RULE
+ {
+    "if": {
+      "not": {
+        "field": "location",
+        "in": "[parameters('allowedLocations')]"
+      }
+    },
+    "then": {
+      "effect": "audit"
+    }
+  }
+POLICY_RULE
+
+  parameters = <<PARAMETERS
+  {
+    "allowedLocations": {
+      "type": "Array",
+      "metadata": {
+        "description": "The list of allowed locations for resources.",
+        "displayName": "Allowed locations",
+        "strongType": "location"
+      }
+    }
+  }
+PARAMETERS
+}
+
+resource "azurerm_policy_assignment" "test" {
+  name                 = "acctestpa-%d"
+  scope                = data.azurerm_subscription.current.id
+  policy_definition_id = azurerm_policy_definition.test.id
+  display_name         = "acctestpa-%d"
+}
+`, ri, ri, ri, ri)
+}
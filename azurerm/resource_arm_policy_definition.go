@@ -2,15 +2,19 @@ package azurerm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"reflect"
 	"strings"
 
 	"time"
 
 	"strconv"
 
-	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-12-01/policy"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-09-01/policy"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/structure"
@@ -61,6 +65,12 @@ func resourceArmPolicyDefinition() *schema.Resource {
 				Required: true,
 			},
 
+			"management_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -70,21 +80,26 @@ func resourceArmPolicyDefinition() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				ValidateFunc:     validation.ValidateJsonString,
-				DiffSuppressFunc: structure.SuppressJsonDiff,
+				DiffSuppressFunc: suppressPolicyJsonDiff,
 			},
 
 			"metadata": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				ValidateFunc:     validation.ValidateJsonString,
-				DiffSuppressFunc: structure.SuppressJsonDiff,
+				DiffSuppressFunc: suppressPolicyJsonDiff,
 			},
 
 			"parameters": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				ValidateFunc:     validation.ValidateJsonString,
-				DiffSuppressFunc: structure.SuppressJsonDiff,
+				DiffSuppressFunc: suppressPolicyJsonDiff,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 		},
 	}
@@ -99,6 +114,7 @@ func resourceArmPolicyDefinitionCreateUpdate(d *schema.ResourceData, meta interf
 	mode := d.Get("mode").(string)
 	displayName := d.Get("display_name").(string)
 	description := d.Get("description").(string)
+	managementGroupID := d.Get("management_group_id").(string)
 
 	properties := policy.DefinitionProperties{
 		PolicyType:  policy.Type(policyType),
@@ -136,7 +152,12 @@ func resourceArmPolicyDefinitionCreateUpdate(d *schema.ResourceData, meta interf
 		DefinitionProperties: &properties,
 	}
 
-	_, err := client.CreateOrUpdate(ctx, name, definition)
+	var err error
+	if managementGroupID != "" {
+		_, err = client.CreateOrUpdateAtManagementGroup(ctx, name, definition, managementGroupID)
+	} else {
+		_, err = client.CreateOrUpdate(ctx, name, definition)
+	}
 	if err != nil {
 		return err
 	}
@@ -146,7 +167,7 @@ func resourceArmPolicyDefinitionCreateUpdate(d *schema.ResourceData, meta interf
 	stateConf := &resource.StateChangeConf{
 		Pending:                   []string{"404"},
 		Target:                    []string{"200"},
-		Refresh:                   policyDefinitionRefreshFunc(ctx, client, name),
+		Refresh:                   policyDefinitionRefreshFunc(ctx, client, name, managementGroupID),
 		Timeout:                   5 * time.Minute,
 		MinTimeout:                10 * time.Second,
 		ContinuousTargetOccurence: 10,
@@ -155,7 +176,9 @@ func resourceArmPolicyDefinitionCreateUpdate(d *schema.ResourceData, meta interf
 		return fmt.Errorf("Error waiting for Policy Definition %q to become available: %s", name, err)
 	}
 
-	resp, err := client.Get(ctx, name)
+	// the etag changes with this write, so the Read below must not report it as an out-of-band edit
+	d.Set("etag", "")
+	resp, err := getPolicyDefinitionByName(ctx, client, name, managementGroupID, false)
 	if err != nil {
 		return err
 	}
@@ -169,12 +192,14 @@ func resourceArmPolicyDefinitionRead(d *schema.ResourceData, meta interface{}) e
 	client := meta.(*ArmClient).policyDefinitionsClient
 	ctx := meta.(*ArmClient).StopContext
 
-	name, err := parsePolicyDefinitionNameFromId(d.Id())
+	name, managementGroupID, isBuiltIn, err := parsePolicyDefinitionNameFromId(d.Id())
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.Get(ctx, name)
+	previousEtag := d.Get("etag").(string)
+
+	resp, err := getPolicyDefinitionByName(ctx, client, name, managementGroupID, isBuiltIn)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			log.Printf("[INFO] Error reading Policy Definition %q - removing from state", d.Id())
@@ -186,6 +211,7 @@ func resourceArmPolicyDefinitionRead(d *schema.ResourceData, meta interface{}) e
 	}
 
 	d.Set("name", resp.Name)
+	d.Set("management_group_id", managementGroupID)
 
 	if props := resp.DefinitionProperties; props != nil {
 		d.Set("policy_type", props.PolicyType)
@@ -193,6 +219,20 @@ func resourceArmPolicyDefinitionRead(d *schema.ResourceData, meta interface{}) e
 		d.Set("display_name", props.DisplayName)
 		d.Set("description", props.Description)
 
+		etag := ""
+		if resp.Response.Response != nil {
+			etag = resp.Response.Response.Header.Get("ETag")
+		}
+		if previousEtag != "" && etag != "" && previousEtag != etag {
+			// the etag changed without Terraform making the change itself - someone edited this
+			// Policy Definition out-of-band. The fields below still refresh from the server as
+			// usual, so any content drift will show up as a plan diff against config; this warning
+			// just makes the out-of-band edit itself visible at refresh time instead of only
+			// showing up as an unexplained diff.
+			log.Printf("[WARN] Policy Definition %q was modified out-of-band (etag changed from %q to %q)", d.Id(), previousEtag, etag)
+		}
+		d.Set("etag", etag)
+
 		if policyRule := props.PolicyRule; policyRule != nil {
 			policyRuleVal := policyRule.(map[string]interface{})
 			policyRuleStr, err := structure.FlattenJsonToString(policyRuleVal)
@@ -200,7 +240,7 @@ func resourceArmPolicyDefinitionRead(d *schema.ResourceData, meta interface{}) e
 				return fmt.Errorf("unable to flatten JSON for `policy_rule`: %s", err)
 			}
 
-			d.Set("policy_rule", policyRuleStr)
+			d.Set("policy_rule", flattenPolicyJsonPreservingOrder(d.Get("policy_rule").(string), policyRuleStr))
 		}
 
 		if metadata := props.Metadata; metadata != nil {
@@ -210,7 +250,7 @@ func resourceArmPolicyDefinitionRead(d *schema.ResourceData, meta interface{}) e
 				return fmt.Errorf("unable to flatten JSON for `metadata`: %s", err)
 			}
 
-			d.Set("metadata", metadataStr)
+			d.Set("metadata", flattenPolicyJsonPreservingOrder(d.Get("metadata").(string), metadataStr))
 		}
 
 		if parameters := props.Parameters; parameters != nil {
@@ -220,7 +260,7 @@ func resourceArmPolicyDefinitionRead(d *schema.ResourceData, meta interface{}) e
 				return fmt.Errorf("unable to flatten JSON for `parameters`: %s", err)
 			}
 
-			d.Set("parameters", parametersStr)
+			d.Set("parameters", flattenPolicyJsonPreservingOrder(d.Get("parameters").(string), parametersStr))
 		}
 	}
 
@@ -231,12 +271,17 @@ func resourceArmPolicyDefinitionDelete(d *schema.ResourceData, meta interface{})
 	client := meta.(*ArmClient).policyDefinitionsClient
 	ctx := meta.(*ArmClient).StopContext
 
-	name, err := parsePolicyDefinitionNameFromId(d.Id())
+	name, managementGroupID, _, err := parsePolicyDefinitionNameFromId(d.Id())
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.Delete(ctx, name)
+	var resp autorest.Response
+	if managementGroupID != "" {
+		resp, err = client.DeleteAtManagementGroup(ctx, name, managementGroupID)
+	} else {
+		resp, err = client.Delete(ctx, name)
+	}
 
 	if err != nil {
 		if utils.ResponseWasNotFound(resp) {
@@ -249,27 +294,142 @@ func resourceArmPolicyDefinitionDelete(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func parsePolicyDefinitionNameFromId(id string) (string, error) {
+// parsePolicyDefinitionNameFromId parses the name - and, for a definition authored at a
+// management group, the management group id - out of a Policy Definition resource id. It
+// recognizes subscription-scoped ids
+// (/subscriptions/{sub}/providers/Microsoft.Authorization/policyDefinitions/{name}),
+// management-group-scoped ids
+// (/providers/Microsoft.Management/managementGroups/{mg}/providers/Microsoft.Authorization/policyDefinitions/{name}),
+// and the unscoped ids Azure assigns to built-in definitions
+// (/providers/Microsoft.Authorization/policyDefinitions/{name}), reported back via the isBuiltIn
+// return value since built-ins have to be fetched differently - see getPolicyDefinitionByName.
+// The returned management group id is empty for a subscription-scoped or built-in definition.
+func parsePolicyDefinitionNameFromId(id string) (name string, managementGroupID string, isBuiltIn bool, err error) {
 	components := strings.Split(id, "/")
 
 	if len(components) == 0 {
-		return "", fmt.Errorf("Azure Policy Definition Id is empty or not formatted correctly: %s", id)
+		return "", "", false, fmt.Errorf("Azure Policy Definition Id is empty or not formatted correctly: %s", id)
+	}
+
+	if len(components) == 7 && strings.EqualFold(components[1], "subscriptions") {
+		return components[6], "", false, nil
+	}
+
+	if len(components) == 9 && strings.EqualFold(components[3], "managementGroups") {
+		return components[8], components[4], false, nil
 	}
 
-	if len(components) != 7 {
-		return "", fmt.Errorf("Azure Policy Definition Id should have 6 segments, got %d: '%s'", len(components)-1, id)
+	if len(components) == 5 && strings.EqualFold(components[1], "providers") && strings.EqualFold(components[2], "Microsoft.Authorization") {
+		return components[4], "", true, nil
 	}
 
-	return components[6], nil
+	return "", "", false, fmt.Errorf("Azure Policy Definition Id should be a subscription, management group or built-in scoped id, got '%s'", id)
 }
 
-func policyDefinitionRefreshFunc(ctx context.Context, client policy.DefinitionsClient, name string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		res, err := client.Get(ctx, name)
+// getPolicyDefinitionByName fetches a Policy Definition by name. Built-ins aren't retrievable via
+// Get - the SDK only exposes them through the ListBuiltIn paged listing - so isBuiltIn (as
+// returned by parsePolicyDefinitionNameFromId) picks that path instead.
+func getPolicyDefinitionByName(ctx context.Context, client policy.DefinitionsClient, name string, managementGroupID string, isBuiltIn bool) (policy.Definition, error) {
+	if managementGroupID != "" {
+		return client.GetAtManagementGroup(ctx, name, managementGroupID)
+	}
+
+	if isBuiltIn {
+		return getBuiltInPolicyDefinitionByName(ctx, client, name)
+	}
+
+	return client.Get(ctx, name)
+}
+
+func getBuiltInPolicyDefinitionByName(ctx context.Context, client policy.DefinitionsClient, name string) (policy.Definition, error) {
+	iterator, err := client.ListBuiltIn(ctx)
+	if err != nil {
+		return policy.Definition{}, err
+	}
+
+	for iterator.NotDone() {
+		for _, candidate := range iterator.Values() {
+			if candidate.Name != nil && strings.EqualFold(*candidate.Name, name) {
+				return candidate, nil
+			}
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return policy.Definition{}, err
+		}
+	}
+
+	return policy.Definition{}, fmt.Errorf("Built-In Policy Definition %q was not found", name)
+}
+
+func policyDefinitionRefreshFunc(ctx context.Context, client policy.DefinitionsClient, name string, managementGroupID string) resource.StateRefreshFunc {
+	return policyEventualConsistencyRefreshFunc(func() (int, error) {
+		res, err := getPolicyDefinitionByName(ctx, client, name, managementGroupID, false)
 		if err != nil {
-			return nil, strconv.Itoa(res.StatusCode), fmt.Errorf("Error issuing read request in policyAssignmentRefreshFunc for Policy Assignment %q: %s", name, err)
+			return res.StatusCode, fmt.Errorf("Error issuing read request in policyDefinitionRefreshFunc for Policy Definition %q: %s", name, err)
 		}
 
-		return res, strconv.Itoa(res.StatusCode), nil
+		return res.StatusCode, nil
+	})
+}
+
+// suppressPolicyJsonDiff suppresses the diff only when old and new are deeply equal as JSON,
+// including array element order - significant for policy_rule's allOf/anyOf/in blocks, which are
+// evaluated top-to-bottom.
+func suppressPolicyJsonDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+
+	if old == "" || new == "" {
+		return false
+	}
+
+	equivalent, err := policyJsonEquivalent(old, new)
+	if err != nil {
+		return false
+	}
+
+	return equivalent
+}
+
+// flattenPolicyJsonPreservingOrder keeps the user's existing JSON when it is equivalent to what
+// the API returned, so `terraform plan` stays clean after import.
+func flattenPolicyJsonPreservingOrder(userValue string, apiValue string) string {
+	if userValue == "" {
+		return apiValue
+	}
+
+	if equivalent, err := policyJsonEquivalent(userValue, apiValue); err == nil && equivalent {
+		return userValue
+	}
+
+	return apiValue
+}
+
+func policyJsonEquivalent(a, b string) (bool, error) {
+	var aVal, bVal interface{}
+	if err := json.Unmarshal([]byte(a), &aVal); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(b), &bVal); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(aVal, bVal), nil
+}
+
+// policyEventualConsistencyRefreshFunc wraps a read call that returns an autorest-style status
+// code into a resource.StateRefreshFunc, so the 404-until-200 polling loop used while Policy
+// Definitions stabilize can be reused by the other Policy resources instead of each reimplementing
+// it - notably by azurerm_policy_assignment, which must wait for its referenced Policy Definition
+// to become visible before the assignment itself can be created.
+func policyEventualConsistencyRefreshFunc(read func() (int, error)) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		statusCode, err := read()
+		if statusCode == http.StatusNotFound {
+			return statusCode, strconv.Itoa(statusCode), nil
+		}
+		return statusCode, strconv.Itoa(statusCode), err
 	}
 }
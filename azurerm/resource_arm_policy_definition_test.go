@@ -0,0 +1,264 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestParsePolicyDefinitionNameFromId(t *testing.T) {
+	cases := []struct {
+		Name                      string
+		Input                     string
+		ExpectedName              string
+		ExpectedManagementGroupID string
+		ExpectedIsBuiltIn         bool
+		ExpectError               bool
+	}{
+		{
+			Name:         "subscription scoped",
+			Input:        "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/policyDefinitions/test",
+			ExpectedName: "test",
+		},
+		{
+			Name:                      "management group scoped",
+			Input:                     "/providers/Microsoft.Management/managementGroups/my-mg/providers/Microsoft.Authorization/policyDefinitions/test",
+			ExpectedName:              "test",
+			ExpectedManagementGroupID: "my-mg",
+		},
+		{
+			Name:              "built-in",
+			Input:             "/providers/Microsoft.Authorization/policyDefinitions/test",
+			ExpectedName:      "test",
+			ExpectedIsBuiltIn: true,
+		},
+		{
+			Name:        "neither shape",
+			Input:       "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/policySetDefinitions",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			name, managementGroupID, isBuiltIn, err := parsePolicyDefinitionNameFromId(tc.Input)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("Expected an error but didn't get one")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if name != tc.ExpectedName {
+				t.Fatalf("Expected name %q but got %q", tc.ExpectedName, name)
+			}
+
+			if managementGroupID != tc.ExpectedManagementGroupID {
+				t.Fatalf("Expected management group id %q but got %q", tc.ExpectedManagementGroupID, managementGroupID)
+			}
+
+			if isBuiltIn != tc.ExpectedIsBuiltIn {
+				t.Fatalf("Expected isBuiltIn %t but got %t", tc.ExpectedIsBuiltIn, isBuiltIn)
+			}
+		})
+	}
+}
+
+func TestPolicyJsonEquivalent(t *testing.T) {
+	cases := []struct {
+		Name     string
+		A        string
+		B        string
+		Expected bool
+	}{
+		{
+			Name:     "identical",
+			A:        `{"foo":"bar"}`,
+			B:        `{"foo":"bar"}`,
+			Expected: true,
+		},
+		{
+			Name:     "different key order is still equivalent",
+			A:        `{"foo":"bar","baz":"qux"}`,
+			B:        `{"baz":"qux","foo":"bar"}`,
+			Expected: true,
+		},
+		{
+			Name:     "different array order is not equivalent",
+			A:        `{"allOf":["a","b"]}`,
+			B:        `{"allOf":["b","a"]}`,
+			Expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			equivalent, err := policyJsonEquivalent(tc.A, tc.B)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if equivalent != tc.Expected {
+				t.Fatalf("Expected %t but got %t", tc.Expected, equivalent)
+			}
+		})
+	}
+}
+
+func TestSuppressPolicyJsonDiff(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Old      string
+		New      string
+		Expected bool
+	}{
+		{
+			Name:     "both empty",
+			Old:      "",
+			New:      "",
+			Expected: true,
+		},
+		{
+			Name:     "one empty",
+			Old:      "",
+			New:      `{"foo":"bar"}`,
+			Expected: false,
+		},
+		{
+			Name:     "reordered array is a real diff",
+			Old:      `{"allOf":["a","b"]}`,
+			New:      `{"allOf":["b","a"]}`,
+			Expected: false,
+		},
+		{
+			Name:     "reformatted but equivalent",
+			Old:      `{"allOf": ["a", "b"]}`,
+			New:      `{"allOf":["a","b"]}`,
+			Expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := suppressPolicyJsonDiff("policy_rule", tc.Old, tc.New, nil)
+			if actual != tc.Expected {
+				t.Fatalf("Expected %t but got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestAccAzureRMPolicyDefinition_basic(t *testing.T) {
+	resourceName := "azurerm_policy_definition.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMPolicyDefinition_basic(ri)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMPolicyDefinitionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMPolicyDefinitionExists(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMPolicyDefinitionExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		client := testAccProvider.Meta().(*ArmClient).policyDefinitionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on policyDefinitionsClient: %+v", err)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: Policy Definition %q does not exist", name)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMPolicyDefinitionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).policyDefinitionsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_policy_definition" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+
+		resp, err := client.Get(ctx, name)
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Policy Definition still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccAzureRMPolicyDefinition_basic(ri int) string {
+	return fmt.Sprintf(`
+resource "azurerm_policy_definition" "test" {
+  name         = "acctestpol-%d"
+  policy_type  = "Custom"
+  mode         = "All"
+  display_name = "acctestpol-%d"
+
+  policy_rule = <<POLICY_RULE
+ {
+    "if": {
+      "not": {
+        "field": "location",
+        "in": "[parameters('allowedLocations')]"
+      }
+    },
+    "then": {
+      "effect": "audit"
+    }
+  }
+POLICY_RULE
+
+  parameters = <<PARAMETERS
+  {
+    "allowedLocations": {
+      "type": "Array",
+      "metadata": {
+        "description": "The list of allowed locations for resources.",
+        "displayName": "Allowed locations",
+        "strongType": "location"
+      }
+    }
+  }
+PARAMETERS
+}
+`, ri, ri)
+}
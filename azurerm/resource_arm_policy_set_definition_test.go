@@ -0,0 +1,129 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestExpandFlattenAzureRmPolicySetDefinitionPolicyDefinitions(t *testing.T) {
+	input := `[{"policyDefinitionId":"/providers/Microsoft.Authorization/policyDefinitions/test"}]`
+
+	refs, err := expandAzureRmPolicySetDefinitionPolicyDefinitions(input)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	if refs == nil || len(*refs) != 1 {
+		t.Fatalf("Expected 1 policy definition reference but got %+v", refs)
+	}
+
+	if (*refs)[0].PolicyDefinitionID == nil || *(*refs)[0].PolicyDefinitionID != "/providers/Microsoft.Authorization/policyDefinitions/test" {
+		t.Fatalf("Unexpected PolicyDefinitionID: %+v", (*refs)[0].PolicyDefinitionID)
+	}
+
+	flattened, err := flattenAzureRmPolicySetDefinitionPolicyDefinitions(refs)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	equivalent, err := policyJsonEquivalent(input, flattened)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	if !equivalent {
+		t.Fatalf("Expected flattened JSON %q to be equivalent to input %q", flattened, input)
+	}
+}
+
+func TestAccAzureRMPolicySetDefinition_basic(t *testing.T) {
+	resourceName := "azurerm_policy_set_definition.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMPolicySetDefinition_basic(ri)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMPolicySetDefinitionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMPolicySetDefinitionExists(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMPolicySetDefinitionExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		client := testAccProvider.Meta().(*ArmClient).policySetDefinitionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on policySetDefinitionsClient: %+v", err)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: Policy Set Definition %q does not exist", name)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMPolicySetDefinitionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).policySetDefinitionsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_policy_set_definition" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+
+		resp, err := client.Get(ctx, name)
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Policy Set Definition still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccAzureRMPolicySetDefinition_basic(ri int) string {
+	return fmt.Sprintf(`
+resource "azurerm_policy_set_definition" "test" {
+  name         = "acctestpolset-%d"
+  policy_type  = "Custom"
+  display_name = "acctestpolset-%d"
+
+  policy_definitions = <<POLICY_DEFINITIONS
+[
+  {
+    "policyDefinitionId": "/providers/Microsoft.Authorization/policyDefinitions/8e3e61b3-0b32-22d5-4edf-55f87fdb5955"
+  }
+]
+POLICY_DEFINITIONS
+}
+`, ri, ri)
+}